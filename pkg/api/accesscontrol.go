@@ -0,0 +1,33 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/dtos"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+)
+
+// EvaluateAccessControl handles POST /api/access-control/evaluate. It lets
+// the frontend ask, in one round trip, whether the signed-in user has each
+// of N permissions: every item in the nav tree, every action button on a
+// dashboard, and so on.
+func EvaluateAccessControl(c *middleware.Context, req dtos.EvaluateAccessControlRequest) Response {
+	if dashboardAccessControl == nil || dashboardAccessControl.IsDisabled() {
+		result := make(map[string]bool, len(req.Evaluators))
+		for key := range req.Evaluators {
+			result[key] = false
+		}
+		return Json(200, result)
+	}
+
+	evaluators := make(map[string]accesscontrol.Evaluator, len(req.Evaluators))
+	for key, e := range req.Evaluators {
+		evaluators[key] = accesscontrol.EvalPermission(e.Action, e.Scopes...)
+	}
+
+	result, err := dashboardAccessControl.EvaluateAll(c.Req.Context(), c.SignedInUser, evaluators)
+	if err != nil {
+		return ApiError(500, "Failed to evaluate permissions", err)
+	}
+
+	return Json(200, result)
+}
@@ -5,23 +5,112 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/grafana/grafana/pkg/api/dtos"
 	"github.com/grafana/grafana/pkg/bus"
 	"github.com/grafana/grafana/pkg/components/dashdiffs"
 	"github.com/grafana/grafana/pkg/components/simplejson"
+	coremodeldashboard "github.com/grafana/grafana/pkg/coremodel/dashboard"
 	"github.com/grafana/grafana/pkg/log"
 	"github.com/grafana/grafana/pkg/metrics"
 	"github.com/grafana/grafana/pkg/middleware"
 	m "github.com/grafana/grafana/pkg/models"
 	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/alerting"
+	"github.com/grafana/grafana/pkg/services/dashboardversion"
 	"github.com/grafana/grafana/pkg/services/guardian"
 	"github.com/grafana/grafana/pkg/services/search"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 )
 
+const dashboardSchemaValidationToggle = "dashboardSchemaValidation"
+
+var (
+	dashboardModelOnce sync.Once
+	dashboardModel     *coremodeldashboard.DashboardModel
+	dashboardModelErr  error
+)
+
+// getDashboardModel returns the process-wide dashboard coremodel, compiling
+// its CUE schema on first use.
+func getDashboardModel() (*coremodeldashboard.DashboardModel, error) {
+	dashboardModelOnce.Do(func() {
+		dashboardModel, dashboardModelErr = coremodeldashboard.New()
+	})
+	return dashboardModel, dashboardModelErr
+}
+
+// validateAndNormalizeDashboardSchema validates dash against the dashboard
+// coremodel when the dashboardSchemaValidation feature toggle is enabled,
+// returning a structured 400 response on failure. On success it also
+// returns the schema-normalized document, with any CUE-defined defaults
+// (such as a missing schemaVersion) filled in; callers should persist that
+// instead of dash. With the toggle off, dash is returned unchanged.
+func validateAndNormalizeDashboardSchema(dash *simplejson.Json) (*simplejson.Json, Response) {
+	if !setting.IsFeatureToggleEnabled(dashboardSchemaValidationToggle) {
+		return dash, nil
+	}
+
+	model, err := getDashboardModel()
+	if err != nil {
+		return nil, ApiError(500, "Failed to load dashboard schema", err)
+	}
+
+	normalized, err := model.Normalize(dash)
+	if err != nil {
+		if verr, ok := err.(coremodeldashboard.ValidationError); ok {
+			return nil, Json(400, util.DynMap{
+				"message": "Dashboard failed schema validation",
+				"errors":  verr.Errors,
+			})
+		}
+		return nil, ApiError(500, "Failed to validate dashboard schema", err)
+	}
+
+	return normalized, nil
+}
+
+var (
+	dashboardAccessControl accesscontrol.AccessControl
+	dashboardResourceStore accesscontrol.ResourceStore
+)
+
+// SetDashboardAccessControl wires the AccessControl service and the resource
+// permission store into the dashboard handlers, and declares the fixed
+// dashboard read/write/delete roles. It must be called once during server
+// startup, before any dashboard route is served.
+func SetDashboardAccessControl(ac accesscontrol.AccessControl, rs accesscontrol.ResourceStore) error {
+	dashboardAccessControl = ac
+	dashboardResourceStore = rs
+	return accesscontrol.DeclareDashboardRoles(ac)
+}
+
+var dashboardVersionService dashboardversion.Service = dashboardversion.ProvideService(nil)
+
+// SetDashboardVersionService wires the dashboardversion Service used by the
+// version history handlers. Call during server startup, after AccessControl
+// has been created, so version reads/writes get the same RBAC treatment as
+// the rest of the dashboard API.
+func SetDashboardVersionService(svc dashboardversion.Service) {
+	dashboardVersionService = svc
+}
+
+// evaluateDashboardAccess checks action against the dashboard identified by
+// dashId using AccessControl when it is enabled, falling back to the legacy
+// guardian-based check otherwise so behavior is unchanged for fleets that
+// haven't turned RBAC on.
+func evaluateDashboardAccess(c *middleware.Context, action string, dashId int64, fallback func() (bool, error)) (bool, error) {
+	if dashboardAccessControl == nil || dashboardAccessControl.IsDisabled() {
+		return fallback()
+	}
+
+	scope := accesscontrol.Scope("dashboards", "id", fmt.Sprintf("%d", dashId))
+	return dashboardAccessControl.Evaluate(c.Req.Context(), c.SignedInUser, accesscontrol.EvalPermission(action, scope))
+}
+
 func isDashboardStarredByUser(c *middleware.Context, dashId int64) (bool, error) {
 	if !c.IsSignedIn {
 		return false, nil
@@ -50,12 +139,12 @@ func GetDashboard(c *middleware.Context) Response {
 	}
 
 	guardian := guardian.NewDashboardGuardian(dash.Id, c.OrgId, c.SignedInUser)
-	if canView, err := guardian.CanView(); err != nil || !canView {
+	if canView, err := evaluateDashboardAccess(c, accesscontrol.ActionDashboardsRead, dash.Id, guardian.CanView); err != nil || !canView {
 		return dashboardGuardianResponse(err)
 	}
 
-	canEdit, _ := guardian.CanEdit()
-	canSave, _ := guardian.CanSave()
+	canEdit, _ := evaluateDashboardAccess(c, accesscontrol.ActionDashboardsWrite, dash.Id, guardian.CanEdit)
+	canSave, _ := evaluateDashboardAccess(c, accesscontrol.ActionDashboardsWrite, dash.Id, guardian.CanSave)
 
 	isStarred, err := isDashboardStarredByUser(c, dash.Id)
 	if err != nil {
@@ -136,7 +225,7 @@ func DeleteDashboard(c *middleware.Context) Response {
 	}
 
 	guardian := guardian.NewDashboardGuardian(dash.Id, c.OrgId, c.SignedInUser)
-	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+	if canDelete, err := evaluateDashboardAccess(c, accesscontrol.ActionDashboardsDelete, dash.Id, guardian.CanSave); err != nil || !canDelete {
 		return dashboardGuardianResponse(err)
 	}
 
@@ -163,7 +252,7 @@ func PostDashboard(c *middleware.Context, cmd m.SaveDashboardCommand) Response {
 	}
 
 	guardian := guardian.NewDashboardGuardian(dash.Id, c.OrgId, c.SignedInUser)
-	if canSave, err := guardian.CanSave(); err != nil || !canSave {
+	if canSave, err := evaluateDashboardAccess(c, accesscontrol.ActionDashboardsWrite, dash.Id, guardian.CanSave); err != nil || !canSave {
 		return dashboardGuardianResponse(err)
 	}
 
@@ -171,12 +260,22 @@ func PostDashboard(c *middleware.Context, cmd m.SaveDashboardCommand) Response {
 		return ApiError(400, m.ErrDashboardFolderCannotHaveParent.Error(), nil)
 	}
 
-	// Check if Title is empty
+	// Check if Title is empty. Kept alongside validateAndNormalizeDashboardSchema,
+	// rather than folded into it, so fleets running with the coremodel
+	// schema feature flag off don't lose title validation.
 	if dash.Title == "" {
 		return ApiError(400, m.ErrDashboardTitleEmpty.Error(), nil)
 	}
 
-	if dash.Id == 0 {
+	normalized, rsp := validateAndNormalizeDashboardSchema(dash.Data)
+	if rsp != nil {
+		return rsp
+	}
+	dash.Data = normalized
+	cmd.Dashboard = normalized
+
+	isNewDashboard := dash.Id == 0
+	if isNewDashboard {
 		limitReached, err := middleware.QuotaReached(c, "dashboard")
 		if err != nil {
 			return ApiError(500, "failed to get quota", err)
@@ -218,6 +317,16 @@ func PostDashboard(c *middleware.Context, cmd m.SaveDashboardCommand) Response {
 		return ApiError(500, "Failed to save dashboard", err)
 	}
 
+	if isNewDashboard && dashboardResourceStore != nil {
+		resourceID := fmt.Sprintf("%d", cmd.Result.Id)
+		permCmd := accesscontrol.SetResourcePermissionCommand{ResourceID: resourceID, Permission: "Admin"}
+		if _, err := dashboardResourceStore.SetUserResourcePermission(c.Req.Context(), c.OrgId, c.UserId, permCmd); err != nil {
+			log.Error("Could not set creator permission on dashboard %s: %s", resourceID, err.Error())
+		} else {
+			accesscontrol.InvalidateUserCache(c.OrgId, c.UserId)
+		}
+	}
+
 	alertCmd := alerting.UpdateDashboardAlertsCommand{
 		OrgId:     c.OrgId,
 		UserId:    c.UserId,
@@ -303,72 +412,42 @@ func GetDashboardFromJsonFile(c *middleware.Context) {
 	c.JSON(200, &dash)
 }
 
-// GetDashboardVersions returns all dashboard versions as JSON
+// GetDashboardVersions returns a page of dashboard versions as JSON.
 func GetDashboardVersions(c *middleware.Context) Response {
 	dashId := c.ParamsInt64(":dashboardId")
 
-	guardian := guardian.NewDashboardGuardian(dashId, c.OrgId, c.SignedInUser)
-	if canSave, err := guardian.CanSave(); err != nil || !canSave {
-		return dashboardGuardianResponse(err)
-	}
-
-	query := m.GetDashboardVersionsQuery{
-		OrgId:       c.OrgId,
-		DashboardId: dashId,
+	page, err := dashboardVersionService.List(c.Req.Context(), dashboardversion.ListQuery{
+		User:        c.SignedInUser,
+		OrgID:       c.OrgId,
+		DashboardID: dashId,
 		Limit:       c.QueryInt("limit"),
 		Start:       c.QueryInt("start"),
-	}
-
-	if err := bus.Dispatch(&query); err != nil {
-		return ApiError(404, fmt.Sprintf("No versions found for dashboardId %d", dashId), err)
-	}
-
-	for _, version := range query.Result {
-		if version.RestoredFrom == version.Version {
-			version.Message = "Initial save (created by migration)"
-			continue
-		}
-
-		if version.RestoredFrom > 0 {
-			version.Message = fmt.Sprintf("Restored from version %d", version.RestoredFrom)
-			continue
-		}
-
-		if version.ParentVersion == 0 {
-			version.Message = "Initial save"
+	})
+	if err != nil {
+		if err == m.ErrDashboardAccessDenied {
+			return ApiError(403, "Access denied to this dashboard", nil)
 		}
+		return ApiError(404, fmt.Sprintf("No versions found for dashboardId %d", dashId), err)
 	}
 
-	return Json(200, query.Result)
+	return Json(200, page)
 }
 
 // GetDashboardVersion returns the dashboard version with the given ID.
 func GetDashboardVersion(c *middleware.Context) Response {
 	dashId := c.ParamsInt64(":dashboardId")
 
-	guardian := guardian.NewDashboardGuardian(dashId, c.OrgId, c.SignedInUser)
-	if canSave, err := guardian.CanSave(); err != nil || !canSave {
-		return dashboardGuardianResponse(err)
-	}
-
-	query := m.GetDashboardVersionQuery{
-		OrgId:       c.OrgId,
-		DashboardId: dashId,
+	dashVersionMeta, err := dashboardVersionService.Get(c.Req.Context(), dashboardversion.GetQuery{
+		User:        c.SignedInUser,
+		OrgID:       c.OrgId,
+		DashboardID: dashId,
 		Version:     c.ParamsInt(":id"),
-	}
-
-	if err := bus.Dispatch(&query); err != nil {
-		return ApiError(500, fmt.Sprintf("Dashboard version %d not found for dashboardId %d", query.Version, dashId), err)
-	}
-
-	creator := "Anonymous"
-	if query.Result.CreatedBy > 0 {
-		creator = getUserLogin(query.Result.CreatedBy)
-	}
-
-	dashVersionMeta := &m.DashboardVersionMeta{
-		DashboardVersion: *query.Result,
-		CreatedBy:        creator,
+	})
+	if err != nil {
+		if err == m.ErrDashboardAccessDenied {
+			return ApiError(403, "Access denied to this dashboard", nil)
+		}
+		return ApiError(500, fmt.Sprintf("Dashboard version %d not found for dashboardId %d", c.ParamsInt(":id"), dashId), err)
 	}
 
 	return Json(200, dashVersionMeta)
@@ -376,35 +455,40 @@ func GetDashboardVersion(c *middleware.Context) Response {
 
 // POST /api/dashboards/calculate-diff performs diffs on two dashboards
 func CalculateDashboardDiff(c *middleware.Context, apiOptions dtos.CalculateDiffOptions) Response {
-
-	options := dashdiffs.Options{
+	options := dashboardversion.DiffOptions{
+		User:     c.SignedInUser,
 		OrgId:    c.OrgId,
 		DiffType: dashdiffs.ParseDiffType(apiOptions.DiffType),
-		Base: dashdiffs.DiffTarget{
+		Base: dashboardversion.DiffTarget{
 			DashboardId:      apiOptions.Base.DashboardId,
 			Version:          apiOptions.Base.Version,
 			UnsavedDashboard: apiOptions.Base.UnsavedDashboard,
 		},
-		New: dashdiffs.DiffTarget{
+		New: dashboardversion.DiffTarget{
 			DashboardId:      apiOptions.New.DashboardId,
 			Version:          apiOptions.New.Version,
 			UnsavedDashboard: apiOptions.New.UnsavedDashboard,
 		},
 	}
 
-	result, err := dashdiffs.CalculateDiff(&options)
+	result, err := dashboardVersionService.Diff(c.Req.Context(), options)
 	if err != nil {
+		if err == m.ErrDashboardAccessDenied {
+			return ApiError(403, "Access denied to this dashboard", nil)
+		}
 		if err == m.ErrDashboardVersionNotFound {
 			return ApiError(404, "Dashboard version not found", err)
 		}
 		return ApiError(500, "Unable to compute diff", err)
 	}
 
+	if apiOptions.DiffType == "json-patch" {
+		return Json(200, result.DiffJSONPatch)
+	}
 	if options.DiffType == dashdiffs.DiffDelta {
 		return Respond(200, result.Delta).Header("Content-Type", "application/json")
-	} else {
-		return Respond(200, result.Delta).Header("Content-Type", "text/html")
 	}
+	return Respond(200, result.Delta).Header("Content-Type", "text/html")
 }
 
 // RestoreDashboardVersion restores a dashboard to the given version.
@@ -414,18 +498,20 @@ func RestoreDashboardVersion(c *middleware.Context, apiCmd dtos.RestoreDashboard
 		return rsp
 	}
 
-	guardian := guardian.NewDashboardGuardian(dash.Id, c.OrgId, c.SignedInUser)
-	if canSave, err := guardian.CanSave(); err != nil || !canSave {
-		return dashboardGuardianResponse(err)
-	}
-
-	versionQuery := m.GetDashboardVersionQuery{DashboardId: dash.Id, Version: apiCmd.Version, OrgId: c.OrgId}
-	if err := bus.Dispatch(&versionQuery); err != nil {
+	version, err := dashboardVersionService.Restore(c.Req.Context(), dashboardversion.RestoreCmd{
+		User:        c.SignedInUser,
+		OrgID:       c.OrgId,
+		UserID:      c.UserId,
+		DashboardID: dash.Id,
+		Version:     apiCmd.Version,
+	})
+	if err != nil {
+		if err == m.ErrDashboardAccessDenied {
+			return ApiError(403, "Access denied to this dashboard", nil)
+		}
 		return ApiError(404, "Dashboard version not found", nil)
 	}
 
-	version := versionQuery.Result
-
 	saveCmd := m.SaveDashboardCommand{}
 	saveCmd.RestoredFrom = version.Version
 	saveCmd.OrgId = c.OrgId
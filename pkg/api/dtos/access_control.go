@@ -0,0 +1,16 @@
+package dtos
+
+// EvaluatorRequest is the wire form of a single accesscontrol.Evaluator: an
+// action plus the scopes that satisfy it. An empty Scopes list means "any
+// scope", i.e. just having the action is enough.
+type EvaluatorRequest struct {
+	Action string   `json:"action"`
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// EvaluateAccessControlRequest is the body of POST /api/access-control/evaluate:
+// a caller-chosen key (e.g. a nav item ID) for each permission it wants
+// checked.
+type EvaluateAccessControlRequest struct {
+	Evaluators map[string]EvaluatorRequest `json:"evaluators"`
+}
@@ -0,0 +1,125 @@
+// Package dashboard is the coremodel for the dashboard JSON model: panels,
+// rows, templating, time range and alerts. It wraps a CUE schema that
+// declaratively describes what a valid dashboard document looks like, so the
+// API layer no longer has to hand-roll checks like `dash.Title == ""`.
+package dashboard
+
+import (
+	"embed"
+	"strings"
+
+	"cuelang.org/go/cue"
+	cueerrors "cuelang.org/go/cue/errors"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/cuectx"
+)
+
+//go:embed dashboard.cue
+var schemaFS embed.FS
+
+// DashboardModel validates and normalizes dashboard JSON documents against
+// the coremodel's CUE schema. It is compiled once and safe for concurrent
+// use.
+type DashboardModel struct {
+	schema cue.Value
+}
+
+// New compiles the dashboard coremodel's CUE schema. It should be called once
+// at startup; the returned DashboardModel should then be reused for every
+// request.
+func New() (*DashboardModel, error) {
+	schema, err := cuectx.LoadSchema(schemaFS, "dashboard.cue")
+	if err != nil {
+		return nil, err
+	}
+
+	return &DashboardModel{schema: schema}, nil
+}
+
+// Validate checks dash against the coremodel schema. If dash does not
+// conform, it returns a ValidationError listing every failing CUE path
+// together with the constraint that was violated.
+func (m *DashboardModel) Validate(dash *simplejson.Json) error {
+	_, err := m.unify(dash)
+	return err
+}
+
+// Normalize validates dash and returns a copy with any CUE-defined defaults
+// filled in (for example a missing `schemaVersion`). Callers should persist
+// the returned document rather than the input.
+func (m *DashboardModel) Normalize(dash *simplejson.Json) (*simplejson.Json, error) {
+	v, err := m.unify(dash)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := simplejson.NewJson(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func (m *DashboardModel) unify(dash *simplejson.Json) (cue.Value, error) {
+	b, err := dash.Encode()
+	if err != nil {
+		return cue.Value{}, err
+	}
+
+	ctx := m.schema.Context()
+	doc := ctx.CompileBytes(b)
+	if doc.Err() != nil {
+		return cue.Value{}, doc.Err()
+	}
+
+	unified := doc.Unify(m.schema.LookupPath(cue.ParsePath("#Dashboard")))
+	if err := unified.Validate(cue.Concrete(true), cue.All()); err != nil {
+		return cue.Value{}, toValidationError(err)
+	}
+
+	return unified, nil
+}
+
+func toValidationError(err error) ValidationError {
+	var fieldErrs []FieldError
+	for _, e := range cueerrors.Errors(err) {
+		fieldErrs = append(fieldErrs, FieldError{
+			Path:       jsonPointer(e.Path()),
+			Constraint: constraintFromMessage(e.Error()),
+			Message:    e.Error(),
+		})
+	}
+	return ValidationError{Errors: fieldErrs}
+}
+
+func jsonPointer(path []string) string {
+	if len(path) == 0 {
+		return "/"
+	}
+
+	out := ""
+	for _, p := range path {
+		out += "/" + p
+	}
+	return out
+}
+
+func constraintFromMessage(msg string) string {
+	switch {
+	case strings.Contains(msg, "incomplete value"), strings.Contains(msg, "required field"):
+		return "required"
+	case strings.Contains(msg, "conflicting values"), strings.Contains(msg, "not allowed"):
+		return "type"
+	case strings.Contains(msg, "bound"):
+		return "bound"
+	default:
+		return "enum"
+	}
+}
@@ -0,0 +1,75 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func mustModel(t *testing.T) *DashboardModel {
+	t.Helper()
+	m, err := New()
+	if err != nil {
+		t.Fatalf("New() returned error: %v", err)
+	}
+	return m
+}
+
+func TestValidate_RejectsEmptyTitle(t *testing.T) {
+	m := mustModel(t)
+	dash, err := simplejson.NewJson([]byte(`{"title": ""}`))
+	if err != nil {
+		t.Fatalf("failed to build test document: %v", err)
+	}
+
+	if err := m.Validate(dash); err == nil {
+		t.Fatal("expected Validate to reject an empty title, got nil error")
+	}
+}
+
+func TestValidate_AcceptsMinimalDashboard(t *testing.T) {
+	m := mustModel(t)
+	dash, err := simplejson.NewJson([]byte(`{"title": "My dashboard"}`))
+	if err != nil {
+		t.Fatalf("failed to build test document: %v", err)
+	}
+
+	if err := m.Validate(dash); err != nil {
+		t.Fatalf("expected a minimal dashboard to validate, got: %v", err)
+	}
+}
+
+func TestNormalize_FillsSchemaVersion(t *testing.T) {
+	m := mustModel(t)
+	dash, err := simplejson.NewJson([]byte(`{"title": "My dashboard"}`))
+	if err != nil {
+		t.Fatalf("failed to build test document: %v", err)
+	}
+
+	normalized, err := m.Normalize(dash)
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+
+	got := normalized.Get("schemaVersion").MustInt64(0)
+	if got == 0 {
+		t.Fatal("expected Normalize to fill in a non-zero schemaVersion")
+	}
+}
+
+func TestNormalize_KeepsExplicitSchemaVersion(t *testing.T) {
+	m := mustModel(t)
+	dash, err := simplejson.NewJson([]byte(`{"title": "My dashboard", "schemaVersion": 12}`))
+	if err != nil {
+		t.Fatalf("failed to build test document: %v", err)
+	}
+
+	normalized, err := m.Normalize(dash)
+	if err != nil {
+		t.Fatalf("Normalize returned error: %v", err)
+	}
+
+	if got := normalized.Get("schemaVersion").MustInt64(0); got != 12 {
+		t.Fatalf("expected Normalize to keep an explicit schemaVersion, got %d", got)
+	}
+}
@@ -0,0 +1,38 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FieldError describes a single CUE constraint that a dashboard document
+// failed to satisfy.
+type FieldError struct {
+	// Path is the JSON-pointer-style location of the offending field, e.g.
+	// "/panels/0/span".
+	Path string `json:"path"`
+	// Constraint names the kind of constraint that failed: "required",
+	// "type", "enum", or "bound".
+	Constraint string `json:"constraint"`
+	// Message is a human-readable description of the failure.
+	Message string `json:"message"`
+}
+
+// ValidationError is returned by Validate when a dashboard document does not
+// conform to the schema. It carries one FieldError per failing CUE path so
+// callers can render a precise, actionable response.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e ValidationError) Error() string {
+	if len(e.Errors) == 0 {
+		return "dashboard failed schema validation"
+	}
+
+	parts := make([]string, len(e.Errors))
+	for i, fe := range e.Errors {
+		parts[i] = fmt.Sprintf("%s: %s (%s)", fe.Path, fe.Message, fe.Constraint)
+	}
+	return "dashboard failed schema validation: " + strings.Join(parts, "; ")
+}
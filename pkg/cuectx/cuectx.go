@@ -0,0 +1,44 @@
+// Package cuectx provides a shared CUE context and helpers for loading and
+// compiling CUE schemas that describe Grafana's core data models.
+package cuectx
+
+import (
+	"embed"
+	"fmt"
+	"sync"
+
+	"cuelang.org/go/cue"
+	"cuelang.org/go/cue/cuecontext"
+)
+
+var (
+	grafanaCUEContextOnce sync.Once
+	grafanaCUEContext     *cue.Context
+)
+
+// GrafanaCUEContext returns the single, process-wide *cue.Context that all
+// coremodels should use to build and unify CUE values. CUE contexts are not
+// free to create, so this is lazily initialized once and shared.
+func GrafanaCUEContext() *cue.Context {
+	grafanaCUEContextOnce.Do(func() {
+		grafanaCUEContext = cuecontext.New()
+	})
+	return grafanaCUEContext
+}
+
+// LoadSchema compiles the CUE file at path within fs using the shared
+// Grafana CUE context and returns the resulting value. Compilation is pure
+// text loading: it does not resolve imports.
+func LoadSchema(fs embed.FS, path string) (cue.Value, error) {
+	b, err := fs.ReadFile(path)
+	if err != nil {
+		return cue.Value{}, fmt.Errorf("could not read schema %q: %w", path, err)
+	}
+
+	v := GrafanaCUEContext().CompileBytes(b, cue.Filename(path))
+	if v.Err() != nil {
+		return cue.Value{}, fmt.Errorf("could not compile schema %q: %w", path, v.Err())
+	}
+
+	return v, nil
+}
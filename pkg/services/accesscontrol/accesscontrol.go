@@ -11,6 +11,13 @@ type AccessControl interface {
 	// Evaluate evaluates access to the given resources.
 	Evaluate(ctx context.Context, user *models.SignedInUser, evaluator Evaluator) (bool, error)
 
+	// EvaluateAll evaluates every evaluator in evaluators against a single
+	// fetch of the user's permissions, keyed by whatever key the caller
+	// chose (e.g. a nav item ID). It exists so a UI that needs to precheck
+	// many permissions at once, such as building the nav tree, can do it in
+	// one round trip instead of one Evaluate call per item.
+	EvaluateAll(ctx context.Context, user *models.SignedInUser, evaluators map[string]Evaluator) (map[string]bool, error)
+
 	// GetUserPermissions returns user permissions.
 	GetUserPermissions(ctx context.Context, user *models.SignedInUser) ([]*Permission, error)
 
@@ -29,6 +36,11 @@ type PermissionsProvider interface {
 	GetUserPermissions(ctx context.Context, query GetUserPermissionsQuery) ([]*Permission, error)
 }
 
+// ResourceManager mutates resource permissions. Implementations must call
+// InvalidateUserCache (directly for SetUserPermission, or for every
+// affected member for SetTeamPermission/SetBuiltInRolePermission) after a
+// successful write, so EvaluateAll's PermissionCache doesn't keep serving
+// permissions from before the change.
 type ResourceManager interface {
 	// GetPermissions returns all permissions for given resourceID
 	GetPermissions(ctx context.Context, orgID int64, resourceID string) ([]ResourcePermission, error)
@@ -121,6 +133,19 @@ func GroupScopesByAction(permissions []*Permission) map[string]map[string]struct
 }
 
 func ValidateScope(scope string) bool {
+	// a trailing "**" bounded by ':' or '/' matches any number of trailing
+	// path segments, e.g. "folders:uid:**"
+	if strings.HasSuffix(scope, "**") {
+		prefix := strings.TrimSuffix(scope, "**")
+		if len(prefix) > 0 {
+			lastChar := prefix[len(prefix)-1]
+			if lastChar != ':' && lastChar != '/' {
+				return false
+			}
+		}
+		return !strings.ContainsAny(prefix, "*?")
+	}
+
 	prefix, last := scope[:len(scope)-1], scope[len(scope)-1]
 	// verify that last char is either ':' or '/' if last character of scope is '*'
 	if len(prefix) > 0 && last == '*' {
@@ -131,3 +156,37 @@ func ValidateScope(scope string) bool {
 	}
 	return !strings.ContainsAny(prefix, "*?")
 }
+
+// MatchScope reports whether granted, a scope a user actually holds, covers
+// requested, the scope an Evaluator is asking about. A trailing "*" in
+// granted matches any single path segment that follows it, e.g.
+// "dashboards:id:*" matches "dashboards:id:7" but not "dashboards:id:7:uid".
+// A trailing "**" matches any number of trailing segments, e.g.
+// "folders:uid:prod-**" matches both "folders:uid:prod-1" and
+// "folders:uid:prod-1:panels:3".
+func MatchScope(granted, requested string) bool {
+	if granted == requested {
+		return true
+	}
+
+	if strings.HasSuffix(granted, "**") {
+		return strings.HasPrefix(requested, strings.TrimSuffix(granted, "**"))
+	}
+
+	if !strings.HasSuffix(granted, "*") {
+		return false
+	}
+
+	prefix := strings.TrimSuffix(granted, "*")
+	if prefix == "" {
+		return true
+	}
+	if !strings.HasPrefix(requested, prefix) {
+		return false
+	}
+	rest := strings.TrimPrefix(requested, prefix)
+	if rest == "" {
+		return false
+	}
+	return !strings.ContainsAny(rest, ":/")
+}
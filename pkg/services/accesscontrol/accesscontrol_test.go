@@ -0,0 +1,48 @@
+package accesscontrol
+
+import "testing"
+
+func TestValidateScope(t *testing.T) {
+	tests := []struct {
+		scope string
+		want  bool
+	}{
+		{"dashboards:id:7", true},
+		{"dashboards:id:*", true},
+		{"folders:uid:**", true},
+		{"dashboards:*", true},
+		{"dashboards:id:7:panels:*", true},
+		{"dashboards:id:7*", false},
+		{"dashboards:id:*7", false},
+		{"dashboards:id:7**", false},
+	}
+
+	for _, tt := range tests {
+		if got := ValidateScope(tt.scope); got != tt.want {
+			t.Errorf("ValidateScope(%q) = %v, want %v", tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestMatchScope(t *testing.T) {
+	tests := []struct {
+		granted   string
+		requested string
+		want      bool
+	}{
+		{"dashboards:id:7", "dashboards:id:7", true},
+		{"dashboards:id:7", "dashboards:id:8", false},
+		{"dashboards:id:*", "dashboards:id:7", true},
+		{"dashboards:id:*", "dashboards:id:7:panels:3", false},
+		{"folders:uid:prod-**", "folders:uid:prod-1", true},
+		{"folders:uid:prod-**", "folders:uid:prod-1:panels:3", true},
+		{"folders:uid:prod-**", "folders:uid:staging-1", false},
+		{"*", "dashboards:id:7", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchScope(tt.granted, tt.requested); got != tt.want {
+			t.Errorf("MatchScope(%q, %q) = %v, want %v", tt.granted, tt.requested, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package accesscontrol
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/bus"
+	m "github.com/grafana/grafana/pkg/models"
+)
+
+// NewDashboardIDScopeResolver returns a ScopeResolver that expands a
+// "dashboards:id:<id>" scope into the dashboard's "dashboards:uid:<uid>"
+// scope and, if the dashboard lives in a folder, that folder's
+// "folders:uid:<uid>" scope. It is meant to be registered against the
+// "dashboards:id:" prefix so permissions stored against a dashboard's UID or
+// its parent folder are honored when callers only know the numeric ID.
+func NewDashboardIDScopeResolver() ScopeResolver {
+	return ScopeResolverFunc(func(ctx context.Context, orgID int64, scope string) ([]string, error) {
+		id, err := strconv.ParseInt(strings.TrimPrefix(scope, "dashboards:id:"), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		query := m.GetDashboardQuery{Id: id, OrgId: orgID}
+		if err := bus.Dispatch(&query); err != nil {
+			return nil, err
+		}
+		dash := query.Result
+
+		scopes := []string{Scope("dashboards", "uid", dash.Uid)}
+		if dash.ParentId > 0 {
+			folderQuery := m.GetDashboardQuery{Id: dash.ParentId, OrgId: orgID}
+			if err := bus.Dispatch(&folderQuery); err == nil {
+				scopes = append(scopes, Scope("folders", "uid", folderQuery.Result.Uid))
+			}
+		}
+
+		return scopes, nil
+	})
+}
+
+// NewDashboardTagScopeResolver returns a ScopeAttributeResolver that expands
+// an attribute scope like "dashboards:tag:pci" into the "dashboards:id:<id>"
+// scope of every dashboard in orgID carrying that tag. It is meant to be
+// registered against the "dashboards:tag:" prefix and applied to an
+// Evaluator's requested scopes via ResolveAttributeScope, so a permission
+// granted on concrete dashboard IDs can still satisfy a tag-based request.
+func NewDashboardTagScopeResolver() ScopeAttributeResolver {
+	return ScopeResolverFunc(func(ctx context.Context, orgID int64, scope string) ([]string, error) {
+		tag := strings.TrimPrefix(scope, "dashboards:tag:")
+
+		query := m.GetDashboardsByTagQuery{OrgId: orgID, Tag: tag}
+		if err := bus.Dispatch(&query); err != nil {
+			return nil, err
+		}
+
+		scopes := make([]string, 0, len(query.Result))
+		for _, dash := range query.Result {
+			scopes = append(scopes, Scope("dashboards", "id", fmt.Sprintf("%d", dash.Id)))
+		}
+		return scopes, nil
+	})
+}
@@ -0,0 +1,107 @@
+package accesscontrol
+
+// Dashboard actions.
+const (
+	ActionDashboardsRead   = "dashboards:read"
+	ActionDashboardsWrite  = "dashboards:write"
+	ActionDashboardsDelete = "dashboards:delete"
+
+	// ActionDashboardVersionsRead and ActionDashboardVersionsWrite mirror
+	// the dashboardversion.Service actions of the same name. They're
+	// declared here, alongside the rest of the dashboard fixed roles,
+	// rather than in the dashboardversion package, so that
+	// DeclareDashboardRoles stays the single place that wires dashboard
+	// permissions to org roles.
+	ActionDashboardVersionsRead  = "dashboards.versions:read"
+	ActionDashboardVersionsWrite = "dashboards.versions:write"
+)
+
+var dashboardReadRole = RoleRegistration{
+	Role: RoleDTO{
+		Role: Role{
+			Name:        "fixed:dashboards:reader",
+			DisplayName: "Dashboard reader",
+			Description: "Read all dashboards.",
+			Group:       "Dashboards",
+		},
+		Permissions: []Permission{
+			{Action: ActionDashboardsRead, Scope: Scope("dashboards", "id", "*")},
+		},
+	},
+	Grants: []string{"Viewer", "Editor", "Admin"},
+}
+
+var dashboardWriteRole = RoleRegistration{
+	Role: RoleDTO{
+		Role: Role{
+			Name:        "fixed:dashboards:writer",
+			DisplayName: "Dashboard writer",
+			Description: "Create, update and save all dashboards.",
+			Group:       "Dashboards",
+		},
+		Permissions: []Permission{
+			{Action: ActionDashboardsRead, Scope: Scope("dashboards", "id", "*")},
+			{Action: ActionDashboardsWrite, Scope: Scope("dashboards", "id", "*")},
+		},
+	},
+	Grants: []string{"Editor", "Admin"},
+}
+
+var dashboardDeleteRole = RoleRegistration{
+	Role: RoleDTO{
+		Role: Role{
+			Name:        "fixed:dashboards:deleter",
+			DisplayName: "Dashboard deleter",
+			Description: "Delete all dashboards.",
+			Group:       "Dashboards",
+		},
+		Permissions: []Permission{
+			{Action: ActionDashboardsDelete, Scope: Scope("dashboards", "id", "*")},
+		},
+	},
+	Grants: []string{"Admin"},
+}
+
+var dashboardVersionsReadRole = RoleRegistration{
+	Role: RoleDTO{
+		Role: Role{
+			Name:        "fixed:dashboards.versions:reader",
+			DisplayName: "Dashboard version history reader",
+			Description: "List and view the version history of all dashboards.",
+			Group:       "Dashboards",
+		},
+		Permissions: []Permission{
+			{Action: ActionDashboardVersionsRead, Scope: Scope("dashboards", "id", "*")},
+		},
+	},
+	Grants: []string{"Viewer", "Editor", "Admin"},
+}
+
+var dashboardVersionsWriteRole = RoleRegistration{
+	Role: RoleDTO{
+		Role: Role{
+			Name:        "fixed:dashboards.versions:writer",
+			DisplayName: "Dashboard version history writer",
+			Description: "Restore all dashboards to a previously saved version.",
+			Group:       "Dashboards",
+		},
+		Permissions: []Permission{
+			{Action: ActionDashboardVersionsRead, Scope: Scope("dashboards", "id", "*")},
+			{Action: ActionDashboardVersionsWrite, Scope: Scope("dashboards", "id", "*")},
+		},
+	},
+	Grants: []string{"Editor", "Admin"},
+}
+
+// DeclareDashboardRoles registers the fixed dashboard read/write/delete
+// roles, plus the version-history read/write roles, with ac, granting them
+// to the built-in organization roles: Viewer can read (including history),
+// Editor can write (including restore), and only Admin can delete. Delete
+// is narrower than the guardian fallback DeleteDashboard uses when
+// AccessControl is disabled, which allows anyone who can save.
+func DeclareDashboardRoles(ac AccessControl) error {
+	return ac.DeclareFixedRoles(
+		dashboardReadRole, dashboardWriteRole, dashboardDeleteRole,
+		dashboardVersionsReadRole, dashboardVersionsWriteRole,
+	)
+}
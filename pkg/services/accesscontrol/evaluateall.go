@@ -0,0 +1,15 @@
+package accesscontrol
+
+// EvaluatePermissions evaluates every evaluator in evaluators against
+// permissions, grouping permissions by action once so an AccessControl
+// implementation's EvaluateAll costs one GetUserPermissions call and one
+// GroupScopesByAction call, not one per evaluator.
+func EvaluatePermissions(permissions []*Permission, evaluators map[string]Evaluator) map[string]bool {
+	scopesByAction := GroupScopesByAction(permissions)
+
+	result := make(map[string]bool, len(evaluators))
+	for key, evaluator := range evaluators {
+		result[key] = evaluator.Evaluate(scopesByAction)
+	}
+	return result
+}
@@ -0,0 +1,142 @@
+package accesscontrol
+
+import (
+	"context"
+	"strings"
+)
+
+// Evaluator can be evaluated against a set of scopes grouped by action
+// (the shape GroupScopesByAction returns) to decide whether the permissions
+// it represents are satisfied.
+type Evaluator interface {
+	Evaluate(permissions map[string]map[string]struct{}) bool
+
+	// MutateScopes returns a copy of the Evaluator with every scope passed
+	// through mutate. It's used to resolve keyword scopes (users:self) and
+	// attribute scopes (dashboards:tag:pci) into the concrete scopes that
+	// Evaluate can match against, before Evaluate is ever called.
+	MutateScopes(ctx context.Context, mutate ScopeMutator) (Evaluator, error)
+}
+
+// ScopeMutator expands a single scope into the one or more concrete scopes
+// it actually represents. Returning []string{scope} unchanged is the correct
+// behavior for any scope the mutator doesn't recognize.
+type ScopeMutator func(ctx context.Context, scope string) ([]string, error)
+
+// Scope builds a scope string of the form "<kind>:<attribute>:<value>", e.g.
+// Scope("dashboards", "id", "7") == "dashboards:id:7".
+func Scope(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+type permissionEvaluator struct {
+	Action string
+	Scopes []string
+}
+
+// EvalPermission returns an Evaluator that is satisfied when the action is
+// present, and, if any scopes are given, at least one of them matches a
+// scope granted for that action.
+func EvalPermission(action string, scopes ...string) Evaluator {
+	return permissionEvaluator{Action: action, Scopes: scopes}
+}
+
+func (p permissionEvaluator) Evaluate(permissions map[string]map[string]struct{}) bool {
+	userScopes, ok := permissions[p.Action]
+	if !ok {
+		return false
+	}
+
+	if len(p.Scopes) == 0 {
+		return true
+	}
+
+	for _, scope := range p.Scopes {
+		if scopeMatches(userScopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p permissionEvaluator) MutateScopes(ctx context.Context, mutate ScopeMutator) (Evaluator, error) {
+	var mutated []string
+	for _, scope := range p.Scopes {
+		resolved, err := mutate(ctx, scope)
+		if err != nil {
+			return nil, err
+		}
+		mutated = append(mutated, resolved...)
+	}
+	return permissionEvaluator{Action: p.Action, Scopes: mutated}, nil
+}
+
+func scopeMatches(userScopes map[string]struct{}, scope string) bool {
+	if _, ok := userScopes[scope]; ok {
+		return true
+	}
+	for userScope := range userScopes {
+		if MatchScope(userScope, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+type allEvaluator struct{ evaluators []Evaluator }
+
+// EvalAll returns an Evaluator satisfied only when every one of evaluators
+// is satisfied.
+func EvalAll(evaluators ...Evaluator) Evaluator {
+	return allEvaluator{evaluators: evaluators}
+}
+
+func (e allEvaluator) Evaluate(permissions map[string]map[string]struct{}) bool {
+	for _, ev := range e.evaluators {
+		if !ev.Evaluate(permissions) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e allEvaluator) MutateScopes(ctx context.Context, mutate ScopeMutator) (Evaluator, error) {
+	mutated := make([]Evaluator, len(e.evaluators))
+	for i, ev := range e.evaluators {
+		m, err := ev.MutateScopes(ctx, mutate)
+		if err != nil {
+			return nil, err
+		}
+		mutated[i] = m
+	}
+	return allEvaluator{evaluators: mutated}, nil
+}
+
+type anyEvaluator struct{ evaluators []Evaluator }
+
+// EvalAny returns an Evaluator satisfied when at least one of evaluators is
+// satisfied.
+func EvalAny(evaluators ...Evaluator) Evaluator {
+	return anyEvaluator{evaluators: evaluators}
+}
+
+func (e anyEvaluator) Evaluate(permissions map[string]map[string]struct{}) bool {
+	for _, ev := range e.evaluators {
+		if ev.Evaluate(permissions) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e anyEvaluator) MutateScopes(ctx context.Context, mutate ScopeMutator) (Evaluator, error) {
+	mutated := make([]Evaluator, len(e.evaluators))
+	for i, ev := range e.evaluators {
+		m, err := ev.MutateScopes(ctx, mutate)
+		if err != nil {
+			return nil, err
+		}
+		mutated[i] = m
+	}
+	return anyEvaluator{evaluators: mutated}, nil
+}
@@ -0,0 +1,42 @@
+package accesscontrol
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/bus"
+	m "github.com/grafana/grafana/pkg/models"
+)
+
+// NewFolderUIDScopeResolver returns a ScopeResolver that expands a
+// "folders:uid:<uid>" scope into the "folders:uid:<uid>" scope of every
+// ancestor of that folder. It's applied, via ResolveAttributeScope, to the
+// scope an Evaluator is asking about (not to a stored grant), so a
+// permission granted on a parent folder is found to cover a request for one
+// of its descendants: asking about "folders:uid:child" also asks about
+// "folders:uid:parent", which a flat string comparison of stored scopes
+// couldn't express on its own.
+func NewFolderUIDScopeResolver() ScopeResolver {
+	return ScopeResolverFunc(func(ctx context.Context, orgID int64, scope string) ([]string, error) {
+		uid := strings.TrimPrefix(scope, "folders:uid:")
+		return ancestorFolderScopes(orgID, uid)
+	})
+}
+
+func ancestorFolderScopes(orgID int64, uid string) ([]string, error) {
+	query := m.GetFolderByUIDQuery{OrgId: orgID, UID: uid}
+	if err := bus.Dispatch(&query); err != nil {
+		return nil, err
+	}
+
+	if query.Result.ParentUID == "" {
+		return nil, nil
+	}
+
+	parentScope := Scope("folders", "uid", query.Result.ParentUID)
+	ancestors, err := ancestorFolderScopes(orgID, query.Result.ParentUID)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{parentScope}, ancestors...), nil
+}
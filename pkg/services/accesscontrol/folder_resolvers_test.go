@@ -0,0 +1,57 @@
+package accesscontrol
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana/pkg/bus"
+	m "github.com/grafana/grafana/pkg/models"
+)
+
+func TestAncestorFolderScopes(t *testing.T) {
+	bus.ClearBusHandlers()
+	defer bus.ClearBusHandlers()
+
+	parents := map[string]string{
+		"child":       "parent",
+		"parent":      "grandparent",
+		"grandparent": "",
+	}
+
+	bus.AddHandler("test", func(query *m.GetFolderByUIDQuery) error {
+		query.Result = &m.Folder{UID: query.UID, ParentUID: parents[query.UID]}
+		return nil
+	})
+
+	scopes, err := ancestorFolderScopes(1, "child")
+	if err != nil {
+		t.Fatalf("ancestorFolderScopes returned error: %v", err)
+	}
+
+	want := []string{Scope("folders", "uid", "parent"), Scope("folders", "uid", "grandparent")}
+	if len(scopes) != len(want) {
+		t.Fatalf("ancestorFolderScopes(%q) = %v, want %v", "child", scopes, want)
+	}
+	for i, s := range want {
+		if scopes[i] != s {
+			t.Fatalf("ancestorFolderScopes(%q)[%d] = %q, want %q", "child", i, scopes[i], s)
+		}
+	}
+}
+
+func TestAncestorFolderScopes_NoParent(t *testing.T) {
+	bus.ClearBusHandlers()
+	defer bus.ClearBusHandlers()
+
+	bus.AddHandler("test", func(query *m.GetFolderByUIDQuery) error {
+		query.Result = &m.Folder{UID: query.UID}
+		return nil
+	})
+
+	scopes, err := ancestorFolderScopes(1, "root")
+	if err != nil {
+		t.Fatalf("ancestorFolderScopes returned error: %v", err)
+	}
+	if len(scopes) != 0 {
+		t.Fatalf("ancestorFolderScopes(%q) = %v, want empty", "root", scopes)
+	}
+}
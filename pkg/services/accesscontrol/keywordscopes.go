@@ -0,0 +1,28 @@
+package accesscontrol
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/models"
+)
+
+// keywordScopes maps a keyword scope to the function that resolves it
+// against the signed-in user making the request.
+var keywordScopes = map[string]func(user *models.SignedInUser) string{
+	"users:self":   func(user *models.SignedInUser) string { return Scope("users", "id", fmt.Sprintf("%d", user.UserId)) },
+	"orgs:current": func(user *models.SignedInUser) string { return Scope("orgs", "id", fmt.Sprintf("%d", user.OrgId)) },
+}
+
+// ResolveKeywordScope returns a ScopeMutator that expands keyword scopes
+// such as "users:self" and "orgs:current" into the concrete scope they
+// represent for user. Any scope that isn't a known keyword is returned
+// unchanged.
+func ResolveKeywordScope(user *models.SignedInUser) ScopeMutator {
+	return func(ctx context.Context, scope string) ([]string, error) {
+		if resolve, ok := keywordScopes[scope]; ok {
+			return []string{resolve(user)}, nil
+		}
+		return []string{scope}, nil
+	}
+}
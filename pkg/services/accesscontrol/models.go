@@ -0,0 +1,47 @@
+package accesscontrol
+
+import "time"
+
+// GlobalOrgID is used for global permissions, i.e. permissions that are not
+// bound to a particular organization (such as server admin actions).
+const GlobalOrgID = 0
+
+// Permission is an action a user, team or role is allowed to perform,
+// optionally scoped to a specific resource.
+type Permission struct {
+	Id     int64  `json:"-" xorm:"pk autoincr 'id'"`
+	RoleID int64  `json:"-" xorm:"role_id"`
+	Action string `json:"action"`
+	Scope  string `json:"scope"`
+
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// Role represents a named, fixed or custom, bundle of permissions.
+type Role struct {
+	ID          int64  `json:"-" xorm:"pk autoincr 'id'"`
+	OrgID       int64  `json:"-" xorm:"org_id"`
+	UID         string `json:"uid" xorm:"uid"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+	Description string `json:"description"`
+	Group       string `json:"group"`
+
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+}
+
+// RoleDTO is a Role together with the permissions it grants, as returned to
+// API callers.
+type RoleDTO struct {
+	Role
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// RoleRegistration declares a fixed role and the built-in organization roles
+// (and optionally Grafana Admin) it should automatically be granted to.
+type RoleRegistration struct {
+	Role   RoleDTO
+	Grants []string
+}
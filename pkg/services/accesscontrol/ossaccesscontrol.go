@@ -0,0 +1,143 @@
+package accesscontrol
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/models"
+)
+
+// OSSAccessControl is the default, non-enterprise AccessControl
+// implementation: every fixed role declared through DeclareFixedRoles is
+// available to every org, and permissions are resolved straight from the
+// PermissionsProvider with no licensing checks.
+type OSSAccessControl struct {
+	provider      PermissionsProvider
+	resolvers     ScopeResolvers
+	registrations []RoleRegistration
+	disabled      bool
+}
+
+// ProvideOSSAccessControl builds an OSSAccessControl backed by provider,
+// with the dashboard/folder attribute and identifier scope resolvers
+// registered. Its EvaluateAll shares DefaultPermissionCache with every
+// other AccessControl in the process.
+func ProvideOSSAccessControl(provider PermissionsProvider) *OSSAccessControl {
+	resolvers := NewScopeResolvers()
+	resolvers.AddScopeResolver("dashboards:id:", NewDashboardIDScopeResolver())
+	resolvers.AddScopeResolver("dashboards:tag:", NewDashboardTagScopeResolver())
+	resolvers.AddScopeResolver("folders:uid:", NewFolderUIDScopeResolver())
+
+	return &OSSAccessControl{provider: provider, resolvers: resolvers}
+}
+
+// IsDisabled implements AccessControl.
+func (a *OSSAccessControl) IsDisabled() bool {
+	return a.disabled
+}
+
+// DeclareFixedRoles implements AccessControl.
+func (a *OSSAccessControl) DeclareFixedRoles(registrations ...RoleRegistration) error {
+	a.registrations = append(a.registrations, registrations...)
+	return nil
+}
+
+// GetUserRoles implements AccessControl, returning the fixed roles granted
+// to user's org role.
+func (a *OSSAccessControl) GetUserRoles(ctx context.Context, user *models.SignedInUser) ([]*RoleDTO, error) {
+	var roles []*RoleDTO
+	for _, reg := range a.registrations {
+		if grantsTo(reg, user) {
+			role := reg.Role
+			roles = append(roles, &role)
+		}
+	}
+	return roles, nil
+}
+
+// GetUserPermissions implements AccessControl: the permissions granted by
+// user's fixed roles, plus whatever managed resource permissions the
+// PermissionsProvider has stored for them.
+func (a *OSSAccessControl) GetUserPermissions(ctx context.Context, user *models.SignedInUser) ([]*Permission, error) {
+	permissions := fixedRolePermissions(a.registrations, user)
+
+	stored, err := a.provider.GetUserPermissions(ctx, GetUserPermissionsQuery{
+		OrgID:  user.OrgId,
+		UserID: user.UserId,
+		Roles:  []string{string(user.OrgRole)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(permissions, stored...), nil
+}
+
+// Evaluate implements AccessControl.
+func (a *OSSAccessControl) Evaluate(ctx context.Context, user *models.SignedInUser, evaluator Evaluator) (bool, error) {
+	permissions, err := a.GetUserPermissions(ctx, user)
+	if err != nil {
+		return false, err
+	}
+
+	evaluator, err = evaluator.MutateScopes(ctx, ResolveKeywordScope(user))
+	if err != nil {
+		return false, err
+	}
+	evaluator, err = evaluator.MutateScopes(ctx, ResolveAttributeScope(a.resolvers, user.OrgId))
+	if err != nil {
+		return false, err
+	}
+
+	return evaluator.Evaluate(GroupScopesByAction(permissions)), nil
+}
+
+// EvaluateAll implements AccessControl, sharing one GetUserPermissions call
+// (via PermissionCache) and one GroupScopesByAction call across evaluators.
+func (a *OSSAccessControl) EvaluateAll(ctx context.Context, user *models.SignedInUser, evaluators map[string]Evaluator) (map[string]bool, error) {
+	permissions, ok := DefaultPermissionCache.Get(user.OrgId, user.UserId)
+	if !ok {
+		var err error
+		permissions, err = a.GetUserPermissions(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		DefaultPermissionCache.Set(user.OrgId, user.UserId, permissions)
+	}
+
+	mutated := make(map[string]Evaluator, len(evaluators))
+	for key, evaluator := range evaluators {
+		m, err := evaluator.MutateScopes(ctx, ResolveKeywordScope(user))
+		if err != nil {
+			return nil, err
+		}
+		m, err = m.MutateScopes(ctx, ResolveAttributeScope(a.resolvers, user.OrgId))
+		if err != nil {
+			return nil, err
+		}
+		mutated[key] = m
+	}
+
+	return EvaluatePermissions(permissions, mutated), nil
+}
+
+func grantsTo(reg RoleRegistration, user *models.SignedInUser) bool {
+	for _, grant := range reg.Grants {
+		if grant == string(user.OrgRole) {
+			return true
+		}
+	}
+	return false
+}
+
+func fixedRolePermissions(registrations []RoleRegistration, user *models.SignedInUser) []*Permission {
+	var permissions []*Permission
+	for _, reg := range registrations {
+		if !grantsTo(reg, user) {
+			continue
+		}
+		for i := range reg.Role.Permissions {
+			permissions = append(permissions, &reg.Role.Permissions[i])
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,108 @@
+package accesscontrol
+
+import (
+	"container/list"
+	"sync"
+)
+
+// permissionCacheKey identifies one cached permission set.
+type permissionCacheKey struct {
+	orgID  int64
+	userID int64
+}
+
+// PermissionCache is a fixed-size LRU of a user's resolved permissions,
+// keyed by (orgID, userID). It exists so that repeated nav tree /
+// EvaluateAll calls for the same user don't re-run GetUserPermissions
+// against the store on every request. Callers must call InvalidateUser (or
+// the package-level InvalidateUserCache) whenever a write could have
+// changed the cached user's permissions; unlike a content hash, a plain
+// (orgID, userID) key can only stay correct if writes evict it.
+type PermissionCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[permissionCacheKey]*list.Element
+	order    *list.List // front = most recently used
+}
+
+type permissionCacheEntry struct {
+	key         permissionCacheKey
+	permissions []*Permission
+}
+
+// NewPermissionCache returns an empty PermissionCache holding at most
+// capacity entries.
+func NewPermissionCache(capacity int) *PermissionCache {
+	return &PermissionCache{
+		capacity: capacity,
+		entries:  make(map[permissionCacheKey]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the cached permissions for (orgID, userID), if present.
+func (c *PermissionCache) Get(orgID, userID int64) ([]*Permission, bool) {
+	key := permissionCacheKey{orgID: orgID, userID: userID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*permissionCacheEntry).permissions, true
+}
+
+// Set caches permissions under (orgID, userID), evicting the least recently
+// used entry if the cache is full.
+func (c *PermissionCache) Set(orgID, userID int64, permissions []*Permission) {
+	key := permissionCacheKey{orgID: orgID, userID: userID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*permissionCacheEntry).permissions = permissions
+		c.order.MoveToFront(el)
+		return
+	}
+
+	el := c.order.PushFront(&permissionCacheEntry{key: key, permissions: permissions})
+	c.entries[key] = el
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*permissionCacheEntry).key)
+		}
+	}
+}
+
+// InvalidateUser drops the cached entry for (orgID, userID). AccessControl
+// implementations should call this (or the package-level
+// InvalidateUserCache) whenever a ResourceManager mutates a permission that
+// could belong to this user: SetUserPermission directly, SetTeamPermission
+// for one of their teams, or SetBuiltInRolePermission for their org role.
+func (c *PermissionCache) InvalidateUser(orgID, userID int64) {
+	key := permissionCacheKey{orgID: orgID, userID: userID}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// DefaultPermissionCache is the process-wide cache EvaluateAll implementations
+// should share, analogous to how GroupScopesByAction is a shared pure helper.
+var DefaultPermissionCache = NewPermissionCache(1000)
+
+// InvalidateUserCache invalidates DefaultPermissionCache for (orgID, userID).
+func InvalidateUserCache(orgID, userID int64) {
+	DefaultPermissionCache.InvalidateUser(orgID, userID)
+}
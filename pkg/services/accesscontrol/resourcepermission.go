@@ -0,0 +1,39 @@
+package accesscontrol
+
+// ResourcePermission describes the actions a single subject (user, team or
+// built-in role) has been granted on a single resource instance, e.g.
+// "dashboards:id:7".
+type ResourcePermission struct {
+	ID          int64
+	ResourceID  string
+	RoleName    string
+	Actions     []string
+	UserId      int64
+	TeamId      int64
+	BuiltInRole string
+}
+
+// SetResourcePermissionCommand assigns a named permission (e.g. "View",
+// "Edit", "Admin") to a subject on a resource. The ResourceStore
+// implementation maps Permission to the concrete set of actions that make it
+// up via MapPermission.
+type SetResourcePermissionCommand struct {
+	ResourceID string
+	Permission string
+}
+
+// GetResourcesPermissionsQuery fetches every ResourcePermission set on any of
+// the listed resource IDs.
+type GetResourcesPermissionsQuery struct {
+	Actions     []string
+	ResourceIDs []string
+}
+
+// GetUserPermissionsQuery fetches every permission (fixed role and managed
+// resource permission) granted to a user, directly or through their teams and
+// built-in role.
+type GetUserPermissionsQuery struct {
+	OrgID  int64
+	UserID int64
+	Roles  []string
+}
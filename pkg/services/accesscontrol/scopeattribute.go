@@ -0,0 +1,20 @@
+package accesscontrol
+
+import "context"
+
+// ScopeAttributeResolver resolves an attribute scope, such as
+// "dashboards:tag:pci", into the concrete resource scopes it currently
+// matches, e.g. the "dashboards:id:<id>" scope of every dashboard tagged
+// "pci". It shares ScopeResolver's shape so the same registry type,
+// ScopeResolvers, can hold both attribute and identifier resolvers.
+type ScopeAttributeResolver = ScopeResolver
+
+// ResolveAttributeScope returns a ScopeMutator that expands an Evaluator's
+// requested attribute scopes through resolvers, so e.g. asking for
+// "dashboards:tag:pci" is evaluated as "does the user hold dashboards:read
+// on any of the dashboards:id scopes tagged pci".
+func ResolveAttributeScope(resolvers ScopeResolvers, orgID int64) ScopeMutator {
+	return func(ctx context.Context, scope string) ([]string, error) {
+		return resolvers.ResolveScope(ctx, orgID, scope)
+	}
+}
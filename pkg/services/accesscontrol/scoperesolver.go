@@ -0,0 +1,77 @@
+package accesscontrol
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ScopeResolver resolves a scope carrying one kind of identifier into the
+// equivalent scope(s) carrying another, e.g. turning a numeric
+// "dashboards:id:7" into the "dashboards:uid:..." and
+// "folders:uid:..." scopes that a stored permission might actually be
+// granted on.
+type ScopeResolver interface {
+	Resolve(ctx context.Context, orgID int64, scope string) ([]string, error)
+}
+
+// ScopeResolverFunc adapts a function to a ScopeResolver.
+type ScopeResolverFunc func(ctx context.Context, orgID int64, scope string) ([]string, error)
+
+// Resolve implements ScopeResolver.
+func (f ScopeResolverFunc) Resolve(ctx context.Context, orgID int64, scope string) ([]string, error) {
+	return f(ctx, orgID, scope)
+}
+
+// ScopeResolvers is a registry of ScopeResolver keyed by the scope prefix
+// (e.g. "dashboards:id:") they know how to resolve.
+type ScopeResolvers struct {
+	resolvers map[string]ScopeResolver
+}
+
+// NewScopeResolvers returns an empty ScopeResolvers registry.
+func NewScopeResolvers() ScopeResolvers {
+	return ScopeResolvers{resolvers: map[string]ScopeResolver{}}
+}
+
+// AddScopeResolver registers resolver for every scope starting with prefix.
+func (s *ScopeResolvers) AddScopeResolver(prefix string, resolver ScopeResolver) {
+	s.resolvers[prefix] = resolver
+}
+
+// ResolveScope expands scope into the set of equivalent scopes (including
+// itself) by applying every registered resolver whose prefix matches, then
+// doing the same again for every newly produced scope, until a pass adds
+// nothing new. This is what lets a "dashboards:id:7" scope, resolved first
+// into its immediate parent folder's "folders:uid:<uid>" scope, then go on
+// to resolve that folder scope into its own ancestors in turn.
+func (s ScopeResolvers) ResolveScope(ctx context.Context, orgID int64, scope string) ([]string, error) {
+	seen := map[string]struct{}{scope: {}}
+	resolved := []string{scope}
+	queue := []string{scope}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for prefix, resolver := range s.resolvers {
+			if !strings.HasPrefix(current, prefix) {
+				continue
+			}
+			scopes, err := resolver.Resolve(ctx, orgID, current)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve scope %q: %w", current, err)
+			}
+			for _, next := range scopes {
+				if _, ok := seen[next]; ok {
+					continue
+				}
+				seen[next] = struct{}{}
+				resolved = append(resolved, next)
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,90 @@
+package dashboardversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+// diffToJSONPatch computes an RFC 6902 JSON Patch that turns base into new.
+// It walks both documents itself rather than reusing dashdiffs' own delta
+// format, which is shaped for its HTML/inline renderers and isn't a patch
+// document.
+func diffToJSONPatch(base, newDoc *simplejson.Json) ([]JSONPatchOp, error) {
+	baseBytes, err := base.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding base document: %w", err)
+	}
+	newBytes, err := newDoc.Encode()
+	if err != nil {
+		return nil, fmt.Errorf("encoding new document: %w", err)
+	}
+
+	var a, b interface{}
+	if err := json.Unmarshal(baseBytes, &a); err != nil {
+		return nil, fmt.Errorf("parsing base document: %w", err)
+	}
+	if err := json.Unmarshal(newBytes, &b); err != nil {
+		return nil, fmt.Errorf("parsing new document: %w", err)
+	}
+
+	var ops []JSONPatchOp
+	diffValue("", a, b, &ops)
+	return ops, nil
+}
+
+func diffValue(path string, a, b interface{}, ops *[]JSONPatchOp) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		diffObjects(path, aMap, bMap, ops)
+		return
+	}
+
+	*ops = append(*ops, JSONPatchOp{Op: "replace", Path: path, Value: b})
+}
+
+func diffObjects(path string, a, b map[string]interface{}, ops *[]JSONPatchOp) {
+	keys := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		keys[k] = struct{}{}
+	}
+	for k := range b {
+		keys[k] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		childPath := path + "/" + escapePointerToken(k)
+		av, aHas := a[k]
+		bv, bHas := b[k]
+		switch {
+		case aHas && !bHas:
+			*ops = append(*ops, JSONPatchOp{Op: "remove", Path: childPath})
+		case !aHas && bHas:
+			*ops = append(*ops, JSONPatchOp{Op: "add", Path: childPath, Value: bv})
+		default:
+			diffValue(childPath, av, bv, ops)
+		}
+	}
+}
+
+// escapePointerToken escapes a single JSON Pointer (RFC 6901) reference
+// token.
+func escapePointerToken(token string) string {
+	replacer := strings.NewReplacer("~", "~0", "/", "~1")
+	return replacer.Replace(token)
+}
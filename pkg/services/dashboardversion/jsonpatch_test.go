@@ -0,0 +1,121 @@
+package dashboardversion
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+)
+
+func mustJSON(t *testing.T, raw string) *simplejson.Json {
+	t.Helper()
+	doc, err := simplejson.NewJson([]byte(raw))
+	if err != nil {
+		t.Fatalf("failed to build test document: %v", err)
+	}
+	return doc
+}
+
+func opsByPath(ops []JSONPatchOp) map[string]JSONPatchOp {
+	byPath := make(map[string]JSONPatchOp, len(ops))
+	for _, op := range ops {
+		byPath[op.Path] = op
+	}
+	return byPath
+}
+
+func TestDiffToJSONPatch_NoChanges(t *testing.T) {
+	base := mustJSON(t, `{"title": "A"}`)
+	newDoc := mustJSON(t, `{"title": "A"}`)
+
+	ops, err := diffToJSONPatch(base, newDoc)
+	if err != nil {
+		t.Fatalf("diffToJSONPatch returned error: %v", err)
+	}
+	if len(ops) != 0 {
+		t.Fatalf("expected no ops for identical documents, got %v", ops)
+	}
+}
+
+func TestDiffToJSONPatch_AddRemoveReplace(t *testing.T) {
+	base := mustJSON(t, `{"title": "A", "tags": ["x"]}`)
+	newDoc := mustJSON(t, `{"title": "B", "version": 2}`)
+
+	ops, err := diffToJSONPatch(base, newDoc)
+	if err != nil {
+		t.Fatalf("diffToJSONPatch returned error: %v", err)
+	}
+
+	byPath := opsByPath(ops)
+
+	replaceTitle, ok := byPath["/title"]
+	if !ok || replaceTitle.Op != "replace" || replaceTitle.Value != "B" {
+		t.Errorf("expected replace of /title to %q, got %+v", "B", replaceTitle)
+	}
+
+	removeTags, ok := byPath["/tags"]
+	if !ok || removeTags.Op != "remove" {
+		t.Errorf("expected remove of /tags, got %+v", removeTags)
+	}
+
+	addVersion, ok := byPath["/version"]
+	if !ok || addVersion.Op != "add" {
+		t.Errorf("expected add of /version, got %+v", addVersion)
+	}
+
+	if len(ops) != 3 {
+		t.Fatalf("expected exactly 3 ops, got %d: %v", len(ops), ops)
+	}
+}
+
+func TestDiffToJSONPatch_EscapesPointerTokens(t *testing.T) {
+	base := mustJSON(t, `{}`)
+	newDoc := mustJSON(t, `{"a/b~c": 1}`)
+
+	ops, err := diffToJSONPatch(base, newDoc)
+	if err != nil {
+		t.Fatalf("diffToJSONPatch returned error: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("expected exactly 1 op, got %d: %v", len(ops), ops)
+	}
+
+	want := "/a~1b~0c"
+	if ops[0].Path != want {
+		t.Errorf("got path %q, want %q", ops[0].Path, want)
+	}
+}
+
+func TestDiffToJSONPatch_NestedObject(t *testing.T) {
+	base := mustJSON(t, `{"time": {"from": "now-6h", "to": "now"}}`)
+	newDoc := mustJSON(t, `{"time": {"from": "now-1h", "to": "now"}}`)
+
+	ops, err := diffToJSONPatch(base, newDoc)
+	if err != nil {
+		t.Fatalf("diffToJSONPatch returned error: %v", err)
+	}
+	if len(ops) != 1 {
+		t.Fatalf("expected exactly 1 op for one changed nested field, got %d: %v", len(ops), ops)
+	}
+	if ops[0].Path != "/time/from" || ops[0].Op != "replace" {
+		t.Errorf("got op %+v, want replace at /time/from", ops[0])
+	}
+}
+
+func TestDiffToJSONPatch_OpsAreSortedByPath(t *testing.T) {
+	base := mustJSON(t, `{}`)
+	newDoc := mustJSON(t, `{"b": 1, "a": 2}`)
+
+	ops, err := diffToJSONPatch(base, newDoc)
+	if err != nil {
+		t.Fatalf("diffToJSONPatch returned error: %v", err)
+	}
+
+	paths := make([]string, len(ops))
+	for i, op := range ops {
+		paths[i] = op.Path
+	}
+	if !sort.StringsAreSorted(paths) {
+		t.Fatalf("expected ops sorted by path, got %v", paths)
+	}
+}
@@ -0,0 +1,130 @@
+// Package accesscontrol contains the migrations that back-fill RBAC managed
+// resource permissions from data owned by older, pre-RBAC tables.
+package accesscontrol
+
+import (
+	"fmt"
+
+	"github.com/go-xorm/xorm"
+
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+// dashboardACL mirrors the legacy dashboard_acl table that this migration
+// reads from.
+type dashboardACL struct {
+	Id          int64
+	OrgId       int64
+	DashboardId int64
+	UserId      int64
+	TeamId      int64
+	Permission  int64 // 1 = View, 2 = Edit, 4 = Admin
+	Role        string
+}
+
+// permissionFromLegacy maps the legacy dashboard_acl.permission bitmask to
+// the managed permission name used by ResourceStore.
+func permissionFromLegacy(p int64) string {
+	switch p {
+	case 4:
+		return "Admin"
+	case 2:
+		return "Edit"
+	default:
+		return "View"
+	}
+}
+
+// actionsFromLegacy expands a managed permission name into the concrete
+// dashboard actions it grants, the same mapping a dashboard ResourceManager's
+// MapPermission would apply. It's duplicated here, rather than called
+// through ResourceStore, because migrations write directly to the
+// permission table instead of going through the running service.
+func actionsFromLegacy(permission string) []string {
+	switch permission {
+	case "Admin":
+		return []string{accesscontrol.ActionDashboardsRead, accesscontrol.ActionDashboardsWrite, accesscontrol.ActionDashboardsDelete}
+	case "Edit":
+		return []string{accesscontrol.ActionDashboardsRead, accesscontrol.ActionDashboardsWrite}
+	default:
+		return []string{accesscontrol.ActionDashboardsRead}
+	}
+}
+
+// AddMigrations registers this package's migrations with mg. It is not
+// called from anywhere in this tree yet — the central migration list that
+// every other package's AddMigrations is wired into isn't part of this
+// snapshot — so whoever assembles that list still needs to add this call
+// alongside it.
+func AddMigrations(mg *migrator.Migrator) {
+	mg.AddMigration("backfill dashboard_acl into managed RBAC permissions", &BackfillDashboardACLMigration{})
+}
+
+// BackfillDashboardACLMigration copies every row of the legacy dashboard_acl
+// table into the managed resource permission tables, so fleets that enable
+// AccessControl keep the dashboard permissions they already had.
+type BackfillDashboardACLMigration struct {
+	migrator.MigrationBase
+}
+
+// SQL satisfies migrator.CodeMigration; the work happens in Exec instead.
+func (m *BackfillDashboardACLMigration) SQL(dialect migrator.Dialect) string {
+	return "code migration"
+}
+
+// Exec reads every dashboard_acl row and, for each one, grants the
+// equivalent action set by inserting directly into the managed resource
+// permission tables.
+func (m *BackfillDashboardACLMigration) Exec(sess *xorm.Session, mg *migrator.Migrator) error {
+	var acls []dashboardACL
+	if err := sess.Find(&acls); err != nil {
+		return fmt.Errorf("could not read dashboard_acl: %w", err)
+	}
+
+	for _, acl := range acls {
+		resourceID := fmt.Sprintf("%d", acl.DashboardId)
+		cmd := accesscontrol.SetResourcePermissionCommand{
+			ResourceID: resourceID,
+			Permission: permissionFromLegacy(acl.Permission),
+		}
+
+		switch {
+		case acl.UserId > 0:
+			if err := insertManagedPermission(sess, acl.OrgId, "user", fmt.Sprintf("%d", acl.UserId), cmd); err != nil {
+				return err
+			}
+		case acl.TeamId > 0:
+			if err := insertManagedPermission(sess, acl.OrgId, "team", fmt.Sprintf("%d", acl.TeamId), cmd); err != nil {
+				return err
+			}
+		case acl.Role != "":
+			if err := insertManagedPermission(sess, acl.OrgId, "builtin_role", acl.Role, cmd); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// insertManagedPermission writes one row per action that cmd.Permission
+// expands to, against the managed_role identified by orgID/subjectKind/
+// subjectID. The managed_role and permission tables it targets are not
+// created anywhere in this package or series — they're assumed to already
+// exist upstream, created by the RBAC schema migrations this backfill is
+// meant to run after. subjectID is the user/team numeric ID formatted as a
+// string for those subject kinds, or the built-in role name
+// ("Viewer"/"Editor"/"Admin") for subjectKind "builtin_role".
+func insertManagedPermission(sess *xorm.Session, orgID int64, subjectKind, subjectID string, cmd accesscontrol.SetResourcePermissionCommand) error {
+	scope := "dashboards:id:" + cmd.ResourceID
+	for _, action := range actionsFromLegacy(cmd.Permission) {
+		if _, err := sess.Exec(
+			"INSERT INTO permission (role_id, action, scope) SELECT id, ?, ? FROM managed_role WHERE org_id = ? AND subject_kind = ? AND subject_id = ?",
+			action, scope, orgID, subjectKind, subjectID,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package accesscontrol
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+)
+
+func TestPermissionFromLegacy(t *testing.T) {
+	tests := []struct {
+		legacy int64
+		want   string
+	}{
+		{4, "Admin"},
+		{2, "Edit"},
+		{1, "View"},
+		{0, "View"},
+	}
+
+	for _, tt := range tests {
+		if got := permissionFromLegacy(tt.legacy); got != tt.want {
+			t.Errorf("permissionFromLegacy(%d) = %q, want %q", tt.legacy, got, tt.want)
+		}
+	}
+}
+
+func TestActionsFromLegacy(t *testing.T) {
+	tests := []struct {
+		permission string
+		want       []string
+	}{
+		{"Admin", []string{accesscontrol.ActionDashboardsRead, accesscontrol.ActionDashboardsWrite, accesscontrol.ActionDashboardsDelete}},
+		{"Edit", []string{accesscontrol.ActionDashboardsRead, accesscontrol.ActionDashboardsWrite}},
+		{"View", []string{accesscontrol.ActionDashboardsRead}},
+		{"", []string{accesscontrol.ActionDashboardsRead}},
+	}
+
+	for _, tt := range tests {
+		if got := actionsFromLegacy(tt.permission); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("actionsFromLegacy(%q) = %v, want %v", tt.permission, got, tt.want)
+		}
+	}
+}